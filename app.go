@@ -4,8 +4,14 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/Ibrahimsyah/sawit-drone/pkg/dronepath"
 )
 
 var (
@@ -23,6 +29,30 @@ type UtilProvider interface {
 
 type App struct {
 	util UtilProvider
+
+	// configPath and format configure App to read the field and tree definitions from a
+	// file instead of prompting via Scanln. They are empty by default so Start keeps
+	// behaving interactively unless WithConfig is called.
+	configPath string
+	format     string
+
+	// planner selects which dronepath.Strategy Start plans the route with. It defaults
+	// to the serpentine sweep used historically.
+	planner string
+
+	// outputFormat selects how Start prints its result: "summary" (the default, a bare
+	// integer), "json" (a summary object), or "trace" (the full per-step NDJSON trace).
+	outputFormat string
+
+	// tracePath, when set, makes Start also write the full per-step NDJSON trace to the
+	// file at this path, regardless of outputFormat.
+	tracePath string
+
+	// sparse switches Start to dronepath.PlanSparse, which skips the plot-by-plot walk
+	// in favor of a closed-form pass over only the trees. It also relaxes the dimension
+	// cap applied to config-file input, since the whole point of -sparse is to handle
+	// fields too large to validate at the usual 50000 cap.
+	sparse bool
 }
 
 func NewApp(util UtilProvider) *App {
@@ -31,7 +61,71 @@ func NewApp(util UtilProvider) *App {
 	}
 }
 
+// WithConfig switches the App to read its input from the file at configPath instead of
+// Scanln. format selects how the file is parsed, either "json" or "yaml". It returns the
+// App so it can be chained with NewApp.
+func (a *App) WithConfig(configPath, format string) *App {
+	a.configPath = configPath
+	a.format = format
+	return a
+}
+
+// WithPlanner selects how Start plans the route: "serpentine" (the default) or
+// "rowmajor" pick that dronepath.Strategy, and "optimized" uses dronepath.PlanOptimized.
+// It returns the App so it can be chained with NewApp.
+func (a *App) WithPlanner(planner string) *App {
+	a.planner = planner
+	return a
+}
+
+// WithOutput selects how Start prints its result, one of "summary" (the default, a bare
+// integer), "json" (a summary object), or "trace" (the full per-step NDJSON trace). It
+// returns the App so it can be chained with NewApp.
+func (a *App) WithOutput(format string) *App {
+	a.outputFormat = format
+	return a
+}
+
+// WithTrace makes Start also write the full per-step NDJSON trace of the route to the
+// file at path, regardless of the selected output format. It returns the App so it can
+// be chained with NewApp.
+func (a *App) WithTrace(path string) *App {
+	a.tracePath = path
+	return a
+}
+
+// WithSparse switches Start to dronepath.PlanSparse and relaxes the dimension cap
+// applied to config-file input, for fields too large to walk plot by plot. Like
+// PlanOptimized, PlanSparse bypasses Plan/Strategy and so does not support -trace. It
+// returns the App so it can be chained with NewApp.
+func (a *App) WithSparse() *App {
+	a.sparse = true
+	return a
+}
+
+// treeInput describes a single tree entry in a JSON/YAML field configuration. In YAML,
+// the "y" key must be quoted (`"y": 1`) since YAML 1.1 otherwise reads the bare word as
+// the boolean true.
+type treeInput struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Height int `json:"height"`
+}
+
+// fieldInput describes the document shape accepted by -config, e.g.
+// {"length":5,"width":3,"trees":[{"x":2,"y":1,"height":5}]}
+type fieldInput struct {
+	Length int         `json:"length"`
+	Width  int         `json:"width"`
+	Trees  []treeInput `json:"trees"`
+}
+
 func (a *App) Start() {
+	if a.configPath != "" {
+		a.startFromConfig()
+		return
+	}
+
 	length, width, count := 0, 0, 0
 	a.util.Scanln(&length, &width, &count)
 	if valid := a.validateInitialInputs(length, width, count); !valid {
@@ -39,8 +133,7 @@ func (a *App) Start() {
 		return
 	}
 
-	// treeMap is a map of coordinate x,y to tree height
-	treeMap := make(map[string]int)
+	trees := make([]dronepath.Tree, 0, count)
 	for i := 0; i < count; i++ {
 		x, y, height := 0, 0, 0
 		a.util.Scanln(&x, &y, &height)
@@ -50,126 +143,143 @@ func (a *App) Start() {
 			return
 		}
 
-		treeKey := a.generateTreeKey(x, y)
-		treeMap[treeKey] = height
+		trees = append(trees, dronepath.Tree{X: x, Y: y, Height: height})
 	}
 
-	distance := a.calculateFlyDistance(length, width, treeMap)
-	fmt.Println(distance)
+	a.planAndOutput(dronepath.Field{Length: length, Width: width, Trees: trees})
 }
 
-// absInt is a method to return absolute integer value of the input
-func (a *App) absInt(input int) int {
-	if input < 0 {
-		return -input
+// startFromConfig reads a.configPath, decodes it per a.format, and runs the same
+// validation and planning as the interactive Scanln path. It exists so the tool can be
+// driven from a file in pipelines and CI instead of a tty.
+func (a *App) startFromConfig() {
+	raw, err := os.ReadFile(a.configPath)
+	if err != nil {
+		a.throwFail()
+		return
 	}
-	return input
-}
-
-// calculateFlyDistance is the core method to calculate total fly distance of the drone
-// on both vertically and horizontally.
-//
-// It accepts length and width of the field, and the map of tree in the field.
-// It returns an integer denoting the distance of the drone
-func (a *App) calculateFlyDistance(length, width int, treeMap map[string]int) int {
-	// initialize the distance the total horizontal fly distance
-	// plus 1 at the beginning as the drone take off
-	// and 1 at the end as the drone lands
-	distance := 1 + a.calculateHorizontalDistance(length, width)*10 + 1
-
-	// Explore every single plot on the field check whether there is a tree
-	// on the current plot. Started from 1, 1
-	x, y := 1, 1
-	currentAltitude := 1 // The current drone altitude
-	for x <= length && y <= width {
-		x, y = a.getNextPlotCoordinate(length, x, y)
-		key := a.generateTreeKey(x, y)
-
-		// if there is no tree on the plot, decrease the drone altitude to 1
-		treeHeight, found := treeMap[key]
-		if !found {
-			if currentAltitude != 1 {
-				deltaAltitude := currentAltitude - 1
-				currentAltitude = 1
-				distance += a.absInt(deltaAltitude)
-			}
 
-			continue
+	if a.format == "yaml" {
+		raw, err = yaml.YAMLToJSON(raw)
+		if err != nil {
+			a.throwFail()
+			return
 		}
-
-		// adjust the altitude
-		deltaAltitude := treeHeight + 1 - currentAltitude
-		currentAltitude = treeHeight + 1
-		distance += a.absInt(deltaAltitude)
 	}
 
-	return distance
-}
+	var input fieldInput
+	if err := json.Unmarshal(raw, &input); err != nil {
+		a.throwFail()
+		return
+	}
 
-// calcualteHorizontalDistance calculates the horizontal distance of the drone in the field
-// based on the given length and width of the field.
-//
-// It returns the distance of the drone will make to fly from the bottom-left-most
-// to the top-right-most point horizontally
-func (a *App) calculateHorizontalDistance(length, width int) int {
-	// if the width is only 1, meaning the drone will only fly straight 1 time
-	// then we return the result as the drone will not come back
-	if width == 1 {
-		return length - 1
+	valid := a.validateInitialInputs(input.Length, input.Width, len(input.Trees))
+	if a.sparse {
+		valid = a.validateSparseInputs(input.Length, input.Width, len(input.Trees))
+	}
+	if !valid {
+		a.throwFail()
+		return
 	}
 
-	// The distance of the drone will be determined by how big the area of the field
-	// plus the step where the drone go north.
-	// For the odd width, we need to add 1 more step as division by 2 will round the result down
-	northSteps := width / 2
-	if width%2 != 0 {
-		northSteps += 1
+	trees := make([]dronepath.Tree, 0, len(input.Trees))
+	for _, tree := range input.Trees {
+		if tree.Height < 1 || tree.Height > 30 {
+			a.throwFail()
+			return
+		}
+
+		trees = append(trees, dronepath.Tree{X: tree.X, Y: tree.Y, Height: tree.Height})
 	}
 
-	return (length-1)*width + northSteps
+	a.planAndOutput(dronepath.Field{Length: input.Length, Width: input.Width, Trees: trees})
 }
 
-// getNextPlotCoordinate is a method that will give the next coordinate x and y
-// to drone for the next plot.
-//
-// It accepts the length of the field and also the current x y coordinate.
-// It returns the x1 and y1 representing the next drone coordinate
-func (a *App) getNextPlotCoordinate(length, x, y int) (x1 int, y1 int) {
-	// if the x is 1, meaning it is in the west-most
-	// we need to check if the y is odd or even to determine which direction
-	// the drone will go (north or east)
-	if x == 1 {
-		if y%2 == 0 {
-			return x, y + 1
+// planAndOutput plans the route over field with the configured strategy and prints the
+// result per a.outputFormat, additionally writing the full trace to a.tracePath if set.
+func (a *App) planAndOutput(field dronepath.Field) {
+	// PlanSparse and PlanOptimized both bypass Plan/Strategy entirely, so neither ever
+	// populates Result.Trace. Fail clearly instead of silently writing an empty trace.
+	if (a.sparse || a.planner == "optimized") && (a.outputFormat == "trace" || a.tracePath != "") {
+		a.throwFail()
+		return
+	}
+
+	var result dronepath.Result
+	switch {
+	case a.sparse:
+		result = dronepath.PlanSparse(field)
+	case a.planner == "optimized":
+		result = dronepath.PlanOptimized(field)
+	default:
+		var opts []dronepath.Option
+		if a.outputFormat == "trace" || a.tracePath != "" {
+			opts = append(opts, dronepath.WithTrace())
 		}
 
-		return x + 1, y
+		result = dronepath.Plan(field, a.strategy(field.Length, field.Width), opts...)
 	}
 
-	// If the x is equal to length, then we check whether the drone has an odd or even y.
-	// If the y is odd then we move the drone to north and keep the x (x, y + 1).
-	// If the y is even then we move the drone to west (x - 1, y)
-	if x == length {
-		if y%2 == 0 {
-			return x - 1, y
+	if a.tracePath != "" {
+		if err := a.writeTrace(result.Trace); err != nil {
+			a.throwFail()
+			return
+		}
+	}
+
+	switch a.outputFormat {
+	case "json":
+		summary, err := json.Marshal(struct {
+			Distance         int `json:"distance"`
+			Plots            int `json:"plots"`
+			TreesEncountered int `json:"treesEncountered"`
+			MaxAltitude      int `json:"maxAltitude"`
+		}{result.Distance, result.Plots, result.TreesEncountered, result.MaxAltitude})
+		if err != nil {
+			a.throwFail()
+			return
+		}
+		fmt.Println(string(summary))
+	case "trace":
+		for _, step := range result.Trace {
+			line, err := json.Marshal(step)
+			if err != nil {
+				a.throwFail()
+				return
+			}
+			fmt.Println(string(line))
 		}
+	default:
+		fmt.Println(result.Distance)
+	}
+}
 
-		return x, y + 1
+// writeTrace writes steps to a.tracePath as NDJSON, one step per line.
+func (a *App) writeTrace(steps []dronepath.Step) error {
+	file, err := os.Create(a.tracePath)
+	if err != nil {
+		return err
 	}
+	defer file.Close()
 
-	if y%2 == 0 {
-		return x - 1, y
+	enc := json.NewEncoder(file)
+	for _, step := range steps {
+		if err := enc.Encode(step); err != nil {
+			return err
+		}
 	}
 
-	return x + 1, y
+	return nil
 }
 
-// generateTreeKey is helper method the generate the map key of the tree
-// by the given coordinate x and y.
-//
-// It will return "x,y" as string
-func (a *App) generateTreeKey(x, y int) string {
-	return fmt.Sprintf("%d,%d", x, y)
+// strategy builds the dronepath.Strategy selected by a.planner for a field of the given
+// length and width.
+func (a *App) strategy(length, width int) dronepath.Strategy {
+	if a.planner == "rowmajor" {
+		return dronepath.NewRowMajor(length, width)
+	}
+
+	return dronepath.NewSerpentine(length, width)
 }
 
 // throwFail throws an error "FAIL" to stderr and then exits the program with status 1
@@ -187,3 +297,13 @@ func (a *App) validateInitialInputs(length, width, count int) bool {
 		length >= 1 && length <= 50000 &&
 		count >= 1 && count <= 50000
 }
+
+// validateSparseInputs is like validateInitialInputs but relaxes the dimension cap to
+// math.MaxInt32, for use with -sparse on fields too large for the usual 50000 cap. The
+// interactive Scanln path always uses validateInitialInputs instead, since it is not
+// practical to drive a field that large through it anyway.
+func (a *App) validateSparseInputs(length, width, count int) bool {
+	return width >= 1 && width <= math.MaxInt32 &&
+		length >= 1 && length <= math.MaxInt32 &&
+		count >= 1 && count <= math.MaxInt32
+}
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/Ibrahimsyah/sawit-drone/pkg/dronepath"
 )
 
 func Test_NewApp(t *testing.T) {
@@ -117,202 +124,141 @@ func TestApp_Start(t *testing.T) {
 	}
 }
 
-func TestApp_calculateFlyDistance(t *testing.T) {
-	type args struct {
-		length  int
-		width   int
-		treeMap map[string]int
-	}
+// captureStdout redirects os.Stdout for the duration of fn and returns everything
+// written to it, so Start's fmt.Println-based output can be asserted on directly.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
 
-	tests := []struct {
-		name string
-		args args
-		want int
-	}{
-		{
-			name: "given no tree then return the horizontal distance + landing + take off",
-			args: args{
-				length:  10,
-				width:   1,
-				treeMap: make(map[string]int),
-			},
-			want: 92,
-		},
-		{
-			name: "given 5 x 1 field with 3 tree on the middle then return 54",
-			args: args{
-				length: 5,
-				width:  1,
-				treeMap: map[string]int{
-					"2,1": 5,
-					"3,1": 3,
-					"4,1": 4,
-				},
-			},
-			want: 54,
-		},
-		{
-			name: "given 5 x 1 field with 3 unordered tree on the middle then return 54",
-			args: args{
-				length: 5,
-				width:  1,
-				treeMap: map[string]int{
-					"3,1": 3,
-					"4,1": 4,
-					"2,1": 5,
-				},
-			},
-			want: 54,
-		},
-		{
-			name: "given 5 x 1 field with 3 tree with same height on the middle then return 62",
-			args: args{
-				length: 5,
-				width:  1,
-				treeMap: map[string]int{
-					"3,1": 10,
-					"4,1": 10,
-					"2,1": 10,
-				},
-			},
-			want: 62,
-		},
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
 	}
+	os.Stdout = w
+	defer func() { os.Stdout = old }()
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			app := &App{}
-			got := app.calculateFlyDistance(test.args.length, test.args.width, test.args.treeMap)
-			assert.Equal(t, test.want, got)
-		})
+	fn()
+
+	assert.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
 	}
+	return string(out)
 }
 
-func TestApp_calculateHorizontalDistance(t *testing.T) {
-	type args struct {
-		length int
-		width  int
-	}
-	tests := []struct {
-		name string
-		args args
-		want int
-	}{
-		{
-			name: "given 5x1 field then return 4",
-			args: args{
-				length: 5,
-				width:  1,
-			},
-			want: 4,
-		},
-		{
-			name: "given 5x2 field then return 9",
-			args: args{
-				length: 5,
-				width:  2,
-			},
-			want: 9,
-		},
-		{
-			name: "given 5x3 field then return 14",
-			args: args{
-				length: 5,
-				width:  3,
-			},
-			want: 14,
-		},
-	}
+// writeFile is a helper that writes content to a file under t.TempDir() and returns
+// its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			app := &App{}
-			got := app.calculateHorizontalDistance(test.args.length, test.args.width)
-			assert.Equal(t, test.want, got)
-		})
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
 	}
+	return path
 }
 
-func TestApp_getNextPlotCoordinate(t *testing.T) {
-	type args struct {
-		length int
-		x      int
-		y      int
-	}
-	tests := []struct {
-		name  string
-		args  args
-		wantX int
-		wantY int
-	}{
-		{
-			name: "given x = 1 then return x + 1, y",
-			args: args{
-				length: 4,
-				x:      1,
-				y:      1,
-			},
-			wantX: 2,
-			wantY: 1,
-		},
-		{
-			name: "given x = 1 and y = 2 then return x, y + 1",
-			args: args{
-				length: 4,
-				x:      1,
-				y:      2,
-			},
-			wantX: 1,
-			wantY: 3,
-		},
-		{
-			name: "given x = length then return x, y + 1",
-			args: args{
-				length: 4,
-				x:      4,
-				y:      1,
-			},
-			wantX: 4,
-			wantY: 2,
-		},
-		{
-			name: "given x = length and y = 2 then return x - 1, y",
-			args: args{
-				length: 4,
-				x:      4,
-				y:      2,
-			},
-			wantX: 3,
-			wantY: 2,
-		},
-		{
-			name: "given x not 1 and not equal to length and y even then return x - 1, y",
-			args: args{
-				length: 4,
-				x:      2,
-				y:      2,
-			},
-			wantX: 1,
-			wantY: 2,
-		},
-		{
-			name: "given x not 1 and not equal to length and y odd then return x + 1, y",
-			args: args{
-				length: 4,
-				x:      2,
-				y:      1,
-			},
-			wantX: 3,
-			wantY: 1,
-		},
-	}
+func TestApp_StartFromConfig_JSON(t *testing.T) {
+	path := writeFile(t, "field.json", `{"length":5,"width":1,"trees":[{"x":2,"y":1,"height":5},{"x":3,"y":1,"height":3},{"x":4,"y":1,"height":4}]}`)
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			app := &App{}
-			gotX, gotY := app.getNextPlotCoordinate(test.args.length, test.args.x, test.args.y)
-			assert.Equal(t, test.wantX, gotX)
-			assert.Equal(t, test.wantY, gotY)
-		})
+	app := NewApp(nil).WithConfig(path, "json")
+	out := captureStdout(t, app.Start)
+
+	assert.Equal(t, "54\n", out)
+}
+
+func TestApp_StartFromConfig_YAML(t *testing.T) {
+	path := writeFile(t, "field.yaml", "length: 5\nwidth: 1\ntrees:\n  - x: 2\n    \"y\": 1\n    height: 5\n  - x: 3\n    \"y\": 1\n    height: 3\n  - x: 4\n    \"y\": 1\n    height: 4\n")
+
+	app := NewApp(nil).WithConfig(path, "yaml")
+	out := captureStdout(t, app.Start)
+
+	assert.Equal(t, "54\n", out)
+}
+
+func TestApp_StartFromConfig_InvalidFile(t *testing.T) {
+	defer func() { osExit = os.Exit }()
+	osExit = func(code int) { panic(code) }
+
+	app := NewApp(nil).WithConfig(filepath.Join(t.TempDir(), "missing.json"), "json")
+	assert.PanicsWithValue(t, 1, app.Start)
+}
+
+func TestApp_StartFromConfig_InvalidTreeHeight(t *testing.T) {
+	defer func() { osExit = os.Exit }()
+	osExit = func(code int) { panic(code) }
+
+	path := writeFile(t, "field.json", `{"length":5,"width":1,"trees":[{"x":2,"y":1,"height":31}]}`)
+
+	app := NewApp(nil).WithConfig(path, "json")
+	assert.PanicsWithValue(t, 1, app.Start)
+}
+
+func TestApp_StartFromConfig_Sparse(t *testing.T) {
+	field := dronepath.Field{Length: 100000, Width: 1, Trees: []dronepath.Tree{{X: 5, Y: 1, Height: 10}}}
+	path := writeFile(t, "field.json", `{"length":100000,"width":1,"trees":[{"x":5,"y":1,"height":10}]}`)
+
+	app := NewApp(nil).WithConfig(path, "json").WithSparse()
+	out := captureStdout(t, app.Start)
+
+	want := dronepath.PlanSparse(field)
+	assert.Equal(t, fmt.Sprintf("%d\n", want.Distance), out)
+}
+
+func TestApp_PlanAndOutput_JSONFormat(t *testing.T) {
+	path := writeFile(t, "field.json", `{"length":5,"width":1,"trees":[{"x":2,"y":1,"height":5}]}`)
+
+	app := NewApp(nil).WithConfig(path, "json").WithOutput("json")
+	out := captureStdout(t, app.Start)
+
+	var got struct {
+		Distance         int `json:"distance"`
+		Plots            int `json:"plots"`
+		TreesEncountered int `json:"treesEncountered"`
+		MaxAltitude      int `json:"maxAltitude"`
 	}
+	assert.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(out)), &got))
+	assert.Equal(t, 1, got.TreesEncountered)
+	assert.Equal(t, 6, got.MaxAltitude)
+}
+
+func TestApp_PlanAndOutput_OptimizedWithTraceFails(t *testing.T) {
+	defer func() { osExit = os.Exit }()
+	osExit = func(code int) { panic(code) }
+
+	configPath := writeFile(t, "field.json", `{"length":5,"width":5,"trees":[{"x":2,"y":1,"height":5}]}`)
+	tracePath := filepath.Join(t.TempDir(), "trace.ndjson")
+
+	app := NewApp(nil).WithConfig(configPath, "json").WithPlanner("optimized").WithTrace(tracePath)
+	assert.PanicsWithValue(t, 1, app.Start)
+}
+
+func TestApp_PlanAndOutput_SparseWithTraceFails(t *testing.T) {
+	defer func() { osExit = os.Exit }()
+	osExit = func(code int) { panic(code) }
+
+	configPath := writeFile(t, "field.json", `{"length":100000,"width":1,"trees":[{"x":5,"y":1,"height":10}]}`)
+	tracePath := filepath.Join(t.TempDir(), "trace.ndjson")
+
+	app := NewApp(nil).WithConfig(configPath, "json").WithSparse().WithTrace(tracePath)
+	assert.PanicsWithValue(t, 1, app.Start)
+}
+
+func TestApp_PlanAndOutput_Trace(t *testing.T) {
+	configPath := writeFile(t, "field.json", `{"length":5,"width":1,"trees":[{"x":2,"y":1,"height":5}]}`)
+	tracePath := filepath.Join(t.TempDir(), "trace.ndjson")
+
+	app := NewApp(nil).WithConfig(configPath, "json").WithTrace(tracePath)
+	captureStdout(t, app.Start)
+
+	data, err := os.ReadFile(tracePath)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.NotEmpty(t, lines)
+
+	var first dronepath.Step
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, dronepath.ActionTakeoff, first.Action)
 }
@@ -0,0 +1,39 @@
+// main.go wires up the command-line flags and the concrete UtilProvider used when the
+// binary is run outside of tests.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+// scanlnUtil is the UtilProvider used in production, backed by fmt.Scanln.
+type scanlnUtil struct{}
+
+func (scanlnUtil) Scanln(target ...any) {
+	fmt.Scanln(target...)
+}
+
+func main() {
+	configPath := flag.String("config", "", "path to a JSON or YAML file describing the field and trees, in place of interactive input")
+	configFormat := flag.String("config-format", "json", "format of the -config file: json or yaml")
+	planner := flag.String("planner", "serpentine", "traversal strategy to plan the route with: serpentine, rowmajor, or optimized (optimized does not support -trace/-format=trace)")
+	output := flag.String("format", "summary", "output format: summary (bare distance), json (summary object), or trace (full NDJSON trace)")
+	tracePath := flag.String("trace", "", "path to write the full per-step NDJSON trace to, independent of -format")
+	sparse := flag.Bool("sparse", false, "skip the plot-by-plot walk and plan in O(count log count), for fields too large to walk plot by plot (does not support -trace/-format=trace)")
+	flag.Parse()
+
+	app := NewApp(scanlnUtil{}).WithPlanner(*planner).WithOutput(*output)
+	if *configPath != "" {
+		app = app.WithConfig(*configPath, *configFormat)
+	}
+	if *tracePath != "" {
+		app = app.WithTrace(*tracePath)
+	}
+	if *sparse {
+		app = app.WithSparse()
+	}
+
+	app.Start()
+}
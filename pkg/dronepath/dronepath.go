@@ -0,0 +1,223 @@
+// Package dronepath implements the path-planning algorithm used to compute how far a
+// spraying drone must fly to cover a rectangular field of trees, independent of how the
+// field and trees are read in.
+package dronepath
+
+import "fmt"
+
+// Tree is a single tree at coordinate X, Y with the given Height.
+type Tree struct {
+	X      int
+	Y      int
+	Height int
+}
+
+// Field describes the rectangular area to be covered and the trees within it.
+type Field struct {
+	Length int
+	Width  int
+	Trees  []Tree
+}
+
+// Result is the outcome of planning a route over a Field.
+type Result struct {
+	// Distance is the total fly distance, combining horizontal travel and altitude
+	// changes, plus take off and landing.
+	Distance int
+
+	// Plots is the number of plots visited after (1, 1).
+	Plots int
+
+	// TreesEncountered is the number of plots visited that had a tree on them.
+	TreesEncountered int
+
+	// MaxAltitude is the highest altitude the drone flew at while executing the plan.
+	MaxAltitude int
+
+	// Trace is the per-step record of the route, populated only when Plan is called
+	// with WithTrace.
+	Trace []Step
+}
+
+// Action identifies what the drone did during a Step.
+type Action string
+
+const (
+	ActionTakeoff Action = "takeoff"
+	ActionMove    Action = "move"
+	ActionAscend  Action = "ascend"
+	ActionDescend Action = "descend"
+	ActionLand    Action = "land"
+)
+
+// Step is a single recorded action the drone takes while executing a Plan. It is meant
+// to make the altitude changes driven by Strategy.Next inspectable
+// step by step, e.g. by writing each Step as a line of NDJSON.
+type Step struct {
+	Step           int    `json:"step"`
+	X              int    `json:"x"`
+	Y              int    `json:"y"`
+	Action         Action `json:"action"`
+	AltitudeBefore int    `json:"altitudeBefore"`
+	AltitudeAfter  int    `json:"altitudeAfter"`
+	DistanceDelta  int    `json:"distanceDelta"`
+	Cumulative     int    `json:"cumulative"`
+}
+
+// Strategy decides, given the drone's current plot, which plot it visits next.
+// Next returns the next x, y coordinate to visit. ok is false once the current plot is
+// outside the field, at which point the drone has finished its route.
+type Strategy interface {
+	Next(x, y int) (x1, y1 int, ok bool)
+}
+
+// Option configures an optional behavior of Plan.
+type Option func(*planConfig)
+
+type planConfig struct {
+	trace bool
+}
+
+// WithTrace makes Plan record a full per-step trace of the route into Result.Trace.
+// Tracing every step is not free, so it is opt-in.
+func WithTrace() Option {
+	return func(c *planConfig) {
+		c.trace = true
+	}
+}
+
+// Plan walks a Field starting at (1, 1) according to strategy and returns the total fly
+// distance. At every plot with a tree, the drone adjusts its altitude to fly just above
+// the tree before moving on; at every plot without one, it drops back down to altitude 1.
+//
+// The horizontal travel cost of each step is the actual grid distance strategy.Next just
+// moved (Serpentine and RowMajor both only ever report adjacent-or-wrapped plots), so the
+// total reflects whichever Strategy was passed in rather than assuming the Serpentine
+// sweep's closed-form distance. The one exception is the final step, which both
+// Strategy implementations report one plot past the field's edge purely to signal that
+// the route is done; that step is never actually flown, so it is excluded from the
+// horizontal cost (it still goes through the usual altitude bookkeeping, matching the
+// behavior the Serpentine sweep has always had).
+func Plan(field Field, strategy Strategy, opts ...Option) Result {
+	var cfg planConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	treeMap := make(map[string]int, len(field.Trees))
+	for _, tree := range field.Trees {
+		treeMap[treeKey(tree.X, tree.Y)] = tree.Height
+	}
+
+	// 1 at the beginning as the drone takes off; horizontal travel and altitude changes
+	// accumulate per step below.
+	distance := 1
+
+	result := Result{MaxAltitude: 1}
+	if cfg.trace {
+		result.Trace = []Step{{Step: 0, X: 1, Y: 1, Action: ActionTakeoff, AltitudeBefore: 0, AltitudeAfter: 1, DistanceDelta: 1, Cumulative: 1}}
+	}
+
+	x, y := 1, 1
+	currentAltitude := 1 // The current drone altitude
+	cumulative := 1
+	for {
+		nextX, nextY, ok := strategy.Next(x, y)
+		if !ok {
+			break
+		}
+		horizontalDelta := 0
+		if nextX <= field.Length && nextY <= field.Width {
+			horizontalDelta = (absInt(nextX-x) + absInt(nextY-y)) * 10
+			result.Plots++
+		}
+		x, y = nextX, nextY
+
+		before := currentAltitude
+		action := ActionMove
+		altitudeDelta := 0
+
+		// if there is no tree on the plot, decrease the drone altitude to 1
+		treeHeight, found := treeMap[treeKey(x, y)]
+		if !found {
+			if currentAltitude != 1 {
+				altitudeDelta = absInt(currentAltitude - 1)
+				currentAltitude = 1
+				action = ActionDescend
+			}
+		} else {
+			// adjust the altitude
+			result.TreesEncountered++
+			deltaAltitude := treeHeight + 1 - currentAltitude
+			currentAltitude = treeHeight + 1
+			altitudeDelta = absInt(deltaAltitude)
+			if deltaAltitude > 0 {
+				action = ActionAscend
+			} else if deltaAltitude < 0 {
+				action = ActionDescend
+			}
+		}
+
+		if currentAltitude > result.MaxAltitude {
+			result.MaxAltitude = currentAltitude
+		}
+
+		delta := horizontalDelta + altitudeDelta
+		distance += delta
+		cumulative += delta
+		if cfg.trace {
+			result.Trace = append(result.Trace, Step{
+				Step:           len(result.Trace),
+				X:              x,
+				Y:              y,
+				Action:         action,
+				AltitudeBefore: before,
+				AltitudeAfter:  currentAltitude,
+				DistanceDelta:  delta,
+				Cumulative:     cumulative,
+			})
+		}
+	}
+
+	distance += 1 // landing
+	cumulative += 1
+	if cfg.trace {
+		result.Trace = append(result.Trace, Step{
+			Step:           len(result.Trace),
+			X:              x,
+			Y:              y,
+			Action:         ActionLand,
+			AltitudeBefore: currentAltitude,
+			AltitudeAfter:  0,
+			DistanceDelta:  1,
+			Cumulative:     cumulative,
+		})
+	}
+
+	result.Distance = distance
+	return result
+}
+
+// absInt returns the absolute value of input.
+func absInt(input int) int {
+	if input < 0 {
+		return -input
+	}
+	return input
+}
+
+// calculateHorizontalDistance calculates the horizontal distance of the drone in the
+// field based on the given length and width of the field.
+//
+// A serpentine sweep visits every plot of the field exactly once via a single-unit move,
+// so the total planar distance it travels is always the number of plots visited after
+// the first one, length*width-1, regardless of width.
+func calculateHorizontalDistance(length, width int) int {
+	return length*width - 1
+}
+
+// treeKey is a helper function to generate the map key of the tree by the given
+// coordinate x and y. It returns "x,y" as string.
+func treeKey(x, y int) string {
+	return fmt.Sprintf("%d,%d", x, y)
+}
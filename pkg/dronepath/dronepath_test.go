@@ -0,0 +1,299 @@
+package dronepath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		want  int
+	}{
+		{
+			name: "given no tree then return the horizontal distance + landing + take off",
+			field: Field{
+				Length: 10,
+				Width:  1,
+			},
+			want: 92,
+		},
+		{
+			name: "given 5 x 1 field with 3 tree on the middle then return 54",
+			field: Field{
+				Length: 5,
+				Width:  1,
+				Trees: []Tree{
+					{X: 2, Y: 1, Height: 5},
+					{X: 3, Y: 1, Height: 3},
+					{X: 4, Y: 1, Height: 4},
+				},
+			},
+			want: 54,
+		},
+		{
+			name: "given 5 x 1 field with 3 unordered tree on the middle then return 54",
+			field: Field{
+				Length: 5,
+				Width:  1,
+				Trees: []Tree{
+					{X: 3, Y: 1, Height: 3},
+					{X: 4, Y: 1, Height: 4},
+					{X: 2, Y: 1, Height: 5},
+				},
+			},
+			want: 54,
+		},
+		{
+			name: "given 5 x 1 field with 3 tree with same height on the middle then return 62",
+			field: Field{
+				Length: 5,
+				Width:  1,
+				Trees: []Tree{
+					{X: 3, Y: 1, Height: 10},
+					{X: 4, Y: 1, Height: 10},
+					{X: 2, Y: 1, Height: 10},
+				},
+			},
+			want: 62,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Plan(test.field, NewSerpentine(test.field.Length, test.field.Width))
+			assert.Equal(t, test.want, got.Distance)
+		})
+	}
+}
+
+func TestPlan_RowMajor(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		want  int
+	}{
+		{
+			name:  "given a field wider than one row then charge the row-wrap horizontal cost, not the serpentine lump",
+			field: Field{Length: 3, Width: 2},
+			want:  72,
+		},
+		{
+			name: "given a row wrap through a tree then still charge the altitude change at the wrapped plot",
+			field: Field{
+				Length: 3,
+				Width:  2,
+				Trees: []Tree{
+					{X: 1, Y: 2, Height: 5},
+				},
+			},
+			want: 82,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Plan(test.field, NewRowMajor(test.field.Length, test.field.Width))
+			assert.Equal(t, test.want, got.Distance)
+		})
+	}
+}
+
+func TestCalculateHorizontalDistance(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		width  int
+		want   int
+	}{
+		{
+			name:   "given 5x1 field then return 4",
+			length: 5,
+			width:  1,
+			want:   4,
+		},
+		{
+			name:   "given 5x2 field then return 9",
+			length: 5,
+			width:  2,
+			want:   9,
+		},
+		{
+			name:   "given 5x3 field then return 14",
+			length: 5,
+			width:  3,
+			want:   14,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := calculateHorizontalDistance(test.length, test.width)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSerpentine_Next(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  Field
+		x, y   int
+		wantX  int
+		wantY  int
+		wantOK bool
+	}{
+		{
+			name:   "given x = 1 then return x + 1, y",
+			field:  Field{Length: 4, Width: 4},
+			x:      1,
+			y:      1,
+			wantX:  2,
+			wantY:  1,
+			wantOK: true,
+		},
+		{
+			name:   "given x = 1 and y = 2 then return x, y + 1",
+			field:  Field{Length: 4, Width: 4},
+			x:      1,
+			y:      2,
+			wantX:  1,
+			wantY:  3,
+			wantOK: true,
+		},
+		{
+			name:   "given x = length then return x, y + 1",
+			field:  Field{Length: 4, Width: 4},
+			x:      4,
+			y:      1,
+			wantX:  4,
+			wantY:  2,
+			wantOK: true,
+		},
+		{
+			name:   "given x = length and y = 2 then return x - 1, y",
+			field:  Field{Length: 4, Width: 4},
+			x:      4,
+			y:      2,
+			wantX:  3,
+			wantY:  2,
+			wantOK: true,
+		},
+		{
+			name:   "given x not 1 and not equal to length and y even then return x - 1, y",
+			field:  Field{Length: 4, Width: 4},
+			x:      2,
+			y:      2,
+			wantX:  1,
+			wantY:  2,
+			wantOK: true,
+		},
+		{
+			name:   "given x not 1 and not equal to length and y odd then return x + 1, y",
+			field:  Field{Length: 4, Width: 4},
+			x:      2,
+			y:      1,
+			wantX:  3,
+			wantY:  1,
+			wantOK: true,
+		},
+		{
+			name:   "given y past the field width then return ok false",
+			field:  Field{Length: 4, Width: 4},
+			x:      1,
+			y:      5,
+			wantX:  1,
+			wantY:  5,
+			wantOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewSerpentine(test.field.Length, test.field.Width)
+			gotX, gotY, gotOK := s.Next(test.x, test.y)
+			assert.Equal(t, test.wantX, gotX)
+			assert.Equal(t, test.wantY, gotY)
+			assert.Equal(t, test.wantOK, gotOK)
+		})
+	}
+}
+
+func TestRowMajor_Next(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  Field
+		x, y   int
+		wantX  int
+		wantY  int
+		wantOK bool
+	}{
+		{
+			name:   "given x before length then return x + 1, y",
+			field:  Field{Length: 4, Width: 2},
+			x:      1,
+			y:      1,
+			wantX:  2,
+			wantY:  1,
+			wantOK: true,
+		},
+		{
+			name:   "given x at length then wrap to x = 1, y + 1",
+			field:  Field{Length: 4, Width: 2},
+			x:      4,
+			y:      1,
+			wantX:  1,
+			wantY:  2,
+			wantOK: true,
+		},
+		{
+			name:   "given y past the field width then return ok false",
+			field:  Field{Length: 4, Width: 2},
+			x:      1,
+			y:      3,
+			wantX:  1,
+			wantY:  3,
+			wantOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRowMajor(test.field.Length, test.field.Width)
+			gotX, gotY, gotOK := r.Next(test.x, test.y)
+			assert.Equal(t, test.wantX, gotX)
+			assert.Equal(t, test.wantY, gotY)
+			assert.Equal(t, test.wantOK, gotOK)
+		})
+	}
+}
+
+func TestPlan_WithTrace(t *testing.T) {
+	field := Field{
+		Length: 5,
+		Width:  1,
+		Trees: []Tree{
+			{X: 2, Y: 1, Height: 5},
+			{X: 3, Y: 1, Height: 3},
+		},
+	}
+
+	got := Plan(field, NewSerpentine(field.Length, field.Width), WithTrace())
+
+	assert.NotEmpty(t, got.Trace)
+	assert.Equal(t, ActionTakeoff, got.Trace[0].Action)
+	assert.Equal(t, ActionLand, got.Trace[len(got.Trace)-1].Action)
+	assert.Equal(t, got.Distance, got.Trace[len(got.Trace)-1].Cumulative)
+	assert.Equal(t, 2, got.TreesEncountered)
+	assert.Equal(t, 6, got.MaxAltitude)
+}
+
+func TestPlan_WithoutTrace(t *testing.T) {
+	field := Field{Length: 5, Width: 1}
+
+	got := Plan(field, NewSerpentine(field.Length, field.Width))
+
+	assert.Nil(t, got.Trace)
+}
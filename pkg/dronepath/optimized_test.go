@@ -0,0 +1,77 @@
+package dronepath
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomField(rnd *rand.Rand, length, width, treeCount int) Field {
+	trees := make([]Tree, 0, treeCount)
+	seen := make(map[[2]int]bool, treeCount)
+	for len(trees) < treeCount {
+		x := rnd.Intn(length) + 1
+		y := rnd.Intn(width) + 1
+		key := [2]int{x, y}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		trees = append(trees, Tree{X: x, Y: y, Height: rnd.Intn(30) + 1})
+	}
+
+	return Field{Length: length, Width: width, Trees: trees}
+}
+
+func TestPlanOptimized_NeverRegressesVsSerpentine(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+
+	for trial := 0; trial < 10; trial++ {
+		field := randomField(rnd, 50, 50, 40)
+
+		serpentine := Plan(field, NewSerpentine(field.Length, field.Width))
+		optimized := PlanOptimized(field)
+
+		assert.LessOrEqualf(t, optimized.Distance, serpentine.Distance,
+			"trial %d: optimized (%d) should never cost more than serpentine (%d)", trial, optimized.Distance, serpentine.Distance)
+	}
+}
+
+func TestPlanOptimized_EmptyFieldFallsBackToSerpentine(t *testing.T) {
+	field := Field{Length: 10, Width: 10}
+
+	optimized := PlanOptimized(field)
+	serpentine := Plan(field, NewSerpentine(field.Length, field.Width))
+
+	assert.Equal(t, serpentine.Distance, optimized.Distance)
+}
+
+func TestPlanOptimized_IgnoresTreeOnStartPlot(t *testing.T) {
+	field := Field{
+		Length: 2,
+		Width:  2,
+		Trees:  []Tree{{X: 1, Y: 1, Height: 30}},
+	}
+
+	optimized := PlanOptimized(field)
+	serpentine := Plan(field, NewSerpentine(field.Length, field.Width))
+
+	assert.Equal(t, serpentine.Distance, optimized.Distance)
+}
+
+func TestPlanOptimized_SingleRowFallsBackToSerpentine(t *testing.T) {
+	field := Field{
+		Length: 10,
+		Width:  1,
+		Trees: []Tree{
+			{X: 3, Y: 1, Height: 5},
+			{X: 7, Y: 1, Height: 2},
+		},
+	}
+
+	optimized := PlanOptimized(field)
+	serpentine := Plan(field, NewSerpentine(field.Length, field.Width))
+
+	assert.Equal(t, serpentine.Distance, optimized.Distance)
+}
@@ -0,0 +1,78 @@
+package dronepath
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSparse_MatchesPlanSerpentine(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+	}{
+		{
+			name: "given 5 x 1 field with 3 tree on the middle",
+			field: Field{
+				Length: 5,
+				Width:  1,
+				Trees: []Tree{
+					{X: 2, Y: 1, Height: 5},
+					{X: 3, Y: 1, Height: 3},
+					{X: 4, Y: 1, Height: 4},
+				},
+			},
+		},
+		{
+			name:  "given a field with no trees",
+			field: Field{Length: 10, Width: 1},
+		},
+		{
+			name: "given a tree sitting on the start plot (1, 1)",
+			field: Field{
+				Length: 5,
+				Width:  1,
+				Trees: []Tree{
+					{X: 1, Y: 1, Height: 17},
+					{X: 3, Y: 1, Height: 4},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sparse := PlanSparse(test.field)
+			serpentine := Plan(test.field, NewSerpentine(test.field.Length, test.field.Width))
+			assert.Equal(t, serpentine.Distance, sparse.Distance)
+		})
+	}
+}
+
+func TestPlanSparse_MatchesPlanSerpentineOnRandomFields(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+
+	for trial := 0; trial < 10; trial++ {
+		field := randomField(rnd, 20, 20, 30)
+
+		sparse := PlanSparse(field)
+		serpentine := Plan(field, NewSerpentine(field.Length, field.Width))
+
+		assert.Equalf(t, serpentine.Distance, sparse.Distance, "trial %d", trial)
+	}
+}
+
+func BenchmarkPlanSparse_1e9Plots(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	trees := make([]Tree, 1000)
+	for i := range trees {
+		trees[i] = Tree{X: rnd.Intn(30000) + 1, Y: rnd.Intn(30000) + 1, Height: rnd.Intn(30) + 1}
+	}
+	field := Field{Length: 31623, Width: 31623, Trees: trees} // ~1e9 plots
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		PlanSparse(field)
+	}
+}
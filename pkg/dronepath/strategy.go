@@ -0,0 +1,76 @@
+package dronepath
+
+// Serpentine visits every plot of the field in a boustrophedon (back-and-forth) sweep:
+// it walks a row all the way to the opposite edge, then steps one row over and walks
+// back. This is the original, and still the default, traversal order.
+type Serpentine struct {
+	Length int
+	Width  int
+}
+
+// NewSerpentine builds a Serpentine strategy over a field of the given length and width.
+func NewSerpentine(length, width int) *Serpentine {
+	return &Serpentine{Length: length, Width: width}
+}
+
+// Next implements Strategy.
+func (s *Serpentine) Next(x, y int) (int, int, bool) {
+	if x > s.Length || y > s.Width {
+		return x, y, false
+	}
+
+	// if the x is 1, meaning it is in the west-most
+	// we need to check if the y is odd or even to determine which direction
+	// the drone will go (north or east)
+	if x == 1 {
+		if y%2 == 0 || x == s.Length {
+			return x, y + 1, true
+		}
+
+		return x + 1, y, true
+	}
+
+	// If the x is equal to length, then we check whether the drone has an odd or even y.
+	// If the y is odd then we move the drone to north and keep the x (x, y + 1).
+	// If the y is even then we move the drone to west (x - 1, y)
+	if x == s.Length {
+		if y%2 == 0 {
+			return x - 1, y, true
+		}
+
+		return x, y + 1, true
+	}
+
+	if y%2 == 0 {
+		return x - 1, y, true
+	}
+
+	return x + 1, y, true
+}
+
+// RowMajor visits every plot left-to-right, row by row, wrapping back to the west edge
+// at the start of each new row instead of reversing direction like Serpentine. It pays
+// more horizontal distance on fields wider than one row, but visits plots in a simpler,
+// predictable order that is easier to reason about for downstream tooling.
+type RowMajor struct {
+	Length int
+	Width  int
+}
+
+// NewRowMajor builds a RowMajor strategy over a field of the given length and width.
+func NewRowMajor(length, width int) *RowMajor {
+	return &RowMajor{Length: length, Width: width}
+}
+
+// Next implements Strategy.
+func (r *RowMajor) Next(x, y int) (int, int, bool) {
+	if x > r.Length || y > r.Width {
+		return x, y, false
+	}
+
+	if x == r.Length {
+		return 1, y + 1, true
+	}
+
+	return x + 1, y, true
+}